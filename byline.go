@@ -3,6 +3,7 @@ package byline
 import (
 	"bufio"
 	"bytes"
+	"encoding/csv"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -16,31 +17,65 @@ var (
 
 	// default field separator
 	defaultFS = regexp.MustCompile(`\s+`)
-	// default line separator
-	defaultRS byte = '\n'
+	// default record separator
+	defaultRS = []byte{'\n'}
 	// for Grep* methods
 	nullBytes = []byte{}
+
+	// ErrRecordTooLong - error for Read, when a record exceeds the configured scan buffer,
+	// see SetBufferSize
+	ErrRecordTooLong = errors.New("byline: record exceeds scan buffer size, see SetBufferSize")
 )
 
 // Reader - line by line Reader
 type Reader struct {
 	scanner     *bufio.Scanner
+	src         io.Reader
 	filterFuncs []func(line []byte) ([]byte, error)
 	awkVars     AWKVars
+
+	inputMode     InputMode
+	csvReader     *csv.Reader
+	csvComma      rune
+	csvCommaSet   bool
+	csvComment    rune
+	csvLazyQuotes bool
+	csvFilterFn   func(row []string, vars AWKVars) ([]string, error)
+
+	continuation byte
+
+	pipeErrCh    chan error
+	pipeStartErr error
+
+	pending    []byte
+	pendingErr error
 }
 
 // AWKVars - settings for AWK mode, see man awk
 type AWKVars struct {
 	NR int            // number of current line (begin from 1)
 	NF int            // fields count in curent line
-	RS byte           // record separator, default is '\n'
+	RS []byte         // record separator, default is '\n'
 	FS *regexp.Regexp // field separator, default is `\s+`
 }
 
+// InputMode - how raw input is parsed into records, see SetInputMode
+type InputMode int
+
+const (
+	// PlainMode - default line-oriented input, no structured parsing (default)
+	PlainMode InputMode = iota
+	// CSVMode - comma separated values input
+	CSVMode
+	// TSVMode - tab separated values input
+	TSVMode
+)
+
 // NewReader - get new line by line Reader
 func NewReader(reader io.Reader) *Reader {
 	lr := &Reader{
 		scanner: bufio.NewScanner(reader),
+		src:     reader,
 		awkVars: AWKVars{
 			RS: defaultRS,
 			FS: defaultFS,
@@ -55,13 +90,40 @@ func (lr *Reader) scanLinesWithNL(data []byte, atEOF bool) (advance int, token [
 	if atEOF && len(data) == 0 {
 		return 0, nil, nil
 	}
-	if i := bytes.IndexByte(data, lr.awkVars.RS); i >= 0 {
-		// We have a full newline-terminated line.
-		return i + 1, data[0 : i+1], nil
+
+	pos := 0
+	var buf []byte
+	for {
+		i := bytes.Index(data[pos:], lr.awkVars.RS)
+		if i < 0 {
+			break
+		}
+		i += pos
+		end := i + len(lr.awkVars.RS)
+
+		if lr.continuation != 0 && i > pos && data[i-1] == lr.continuation {
+			// The line ends with the continuation marker: drop marker+RS and
+			// keep scanning so it's joined with the following physical line.
+			buf = append(buf, data[pos:i-1]...)
+			pos = end
+			continue
+		}
+
+		if buf == nil {
+			// We have a full newline-terminated line.
+			return end, data[0:end], nil
+		}
+		buf = append(buf, data[pos:end]...)
+		return end, buf, nil
 	}
-	// If we're at EOF, we have a final, non-terminated line. Return it.
+	// If we're at EOF, we have a final, non-terminated line (or a dangling
+	// continuation). Return whatever is buffered.
 	if atEOF {
-		return len(data), data, nil
+		if buf == nil {
+			return len(data), data, nil
+		}
+		buf = append(buf, data[pos:]...)
+		return len(data), buf, nil
 	}
 
 	// Request more data.
@@ -70,6 +132,16 @@ func (lr *Reader) scanLinesWithNL(data []byte, atEOF bool) (advance int, token [
 
 // Read - implement io.Reader interface
 func (lr *Reader) Read(p []byte) (n int, err error) {
+	if lr.pending != nil {
+		return lr.deliver(p, lr.pending, lr.pendingErr)
+	}
+	if lr.pipeStartErr != nil {
+		return 0, lr.pipeStartErr
+	}
+	if lr.inputMode != PlainMode {
+		return lr.readCSV(p)
+	}
+
 	var (
 		bufErr    error
 		lineBytes []byte
@@ -94,13 +166,36 @@ func (lr *Reader) Read(p []byte) (n int, err error) {
 	} else {
 		bufErr = lr.scanner.Err()
 		lineBytes = nullBytes
-		if bufErr == nil {
+		switch {
+		case bufErr == nil:
 			bufErr = io.EOF
+			if lr.pipeErrCh != nil {
+				if pipeErr := <-lr.pipeErrCh; pipeErr != nil {
+					bufErr = pipeErr
+				}
+			}
+		case errors.Is(bufErr, bufio.ErrTooLong):
+			bufErr = ErrRecordTooLong
 		}
 	}
 
-	copy(p, lineBytes)
-	return len(lineBytes), bufErr
+	return lr.deliver(p, lineBytes, bufErr)
+}
+
+// deliver - copy as much of line into p as fits, buffering any remainder (and the error
+// that accompanied it) to be handed out on subsequent Read calls. This is what keeps
+// Read from ever returning n > len(p), which io.Copy (and any other io.Reader caller)
+// relies on.
+func (lr *Reader) deliver(p, line []byte, lineErr error) (int, error) {
+	if len(line) > len(p) {
+		lr.pending = line[len(p):]
+		lr.pendingErr = lineErr
+		return copy(p, line), nil
+	}
+
+	lr.pending = nil
+	lr.pendingErr = nil
+	return copy(p, line), lineErr
 }
 
 // Map - set filter function for process each line
@@ -131,6 +226,31 @@ func (lr *Reader) MapStringErr(filterFn func(string) (string, error)) *Reader {
 	})
 }
 
+// Each - invoke filterFn for each line without modifying it, for side effects like
+// counters, loggers or metrics stages
+func (lr *Reader) Each(filterFn func([]byte)) *Reader {
+	return lr.Map(func(line []byte) []byte {
+		filterFn(line)
+		return line
+	})
+}
+
+// EachString - string variant of Each
+func (lr *Reader) EachString(filterFn func(string)) *Reader {
+	return lr.MapString(func(line string) string {
+		filterFn(line)
+		return line
+	})
+}
+
+// EachErr - like Each, but filterFn can return an error (io.EOF or ErrOmitLine for
+// example) to halt or skip the current line
+func (lr *Reader) EachErr(filterFn func([]byte) error) *Reader {
+	return lr.MapErr(func(line []byte) ([]byte, error) {
+		return line, filterFn(line)
+	})
+}
+
 // Grep - grep lines by func
 func (lr *Reader) Grep(filterFn func([]byte) bool) *Reader {
 	return lr.MapErr(func(line []byte) ([]byte, error) {
@@ -158,16 +278,42 @@ func (lr *Reader) GrepByRegexp(re *regexp.Regexp) *Reader {
 
 // SetRS - set lines (records) separator
 func (lr *Reader) SetRS(rs byte) *Reader {
+	lr.awkVars.RS = []byte{rs}
+	return lr
+}
+
+// SetRSBytes - set a multi-byte lines (records) separator, e.g. "\r\n" or "\n\n" for
+// paragraph mode, or any other sentinel sequence. Note that "\n\n" only matches that
+// literal two-byte sentinel: unlike AWK's real paragraph mode, it does not collapse
+// runs of more than one blank line, so a third consecutive newline starts the next
+// record and a fourth turns the pair into an empty record holding just the separator.
+func (lr *Reader) SetRSBytes(rs []byte) *Reader {
 	lr.awkVars.RS = rs
 	return lr
 }
 
+// SetBufferSize - set the initial and max size of the scan buffer, lifting the default
+// 64 KiB bufio.Scanner limit for long records (e.g. JSON-lines). Read returns
+// ErrRecordTooLong instead of bufio's opaque ErrTooLong when a record exceeds max.
+func (lr *Reader) SetBufferSize(initial, max int) *Reader {
+	lr.scanner.Buffer(make([]byte, initial), max)
+	return lr
+}
+
 // SetFS - set field separator for AWK mode
 func (lr *Reader) SetFS(fs *regexp.Regexp) *Reader {
 	lr.awkVars.FS = fs
 	return lr
 }
 
+// SetLineContinuation - join a physical line ending in marker (typically '\\') with the
+// line that follows it before the filter chain sees it, like a makefile backslash
+// continuation. NR then counts logical lines rather than physical ones.
+func (lr *Reader) SetLineContinuation(marker byte) *Reader {
+	lr.continuation = marker
+	return lr
+}
+
 // AWKMode - process lines with AWK like mode
 func (lr *Reader) AWKMode(filterFn func(line string, fields []string, vars AWKVars) (string, error)) *Reader {
 	return lr.MapStringErr(func(line string) (string, error) {
@@ -0,0 +1,204 @@
+package byline
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestSetBufferSizeLongRecord exercises this method's own pitch: processing a record
+// (e.g. a long JSON-line) past the default 64KiB bufio.Scanner limit.
+func TestSetBufferSizeLongRecord(t *testing.T) {
+	long := strings.Repeat("x", 100000)
+	r := NewReader(strings.NewReader(long+"\n")).SetBufferSize(1024, 200000)
+
+	out, err := r.ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out != long+"\n" {
+		t.Fatalf("got len=%d, want len=%d", len(out), len(long)+1)
+	}
+}
+
+// TestSetBufferSizeErrRecordTooLong checks that exceeding the configured max surfaces
+// the documented ErrRecordTooLong instead of bufio's opaque ErrTooLong.
+func TestSetBufferSizeErrRecordTooLong(t *testing.T) {
+	long := strings.Repeat("x", 200)
+	r := NewReader(strings.NewReader(long+"\n")).SetBufferSize(16, 32)
+
+	_, err := r.ReadAllString()
+	if err != ErrRecordTooLong {
+		t.Fatalf("expected ErrRecordTooLong, got %v", err)
+	}
+}
+
+// TestSetLineContinuationJoinsLines checks that a physical line ending in the marker
+// is joined with the line that follows it, with the marker and the intervening
+// newline both dropped from the logical line delivered to the caller.
+func TestSetLineContinuationJoinsLines(t *testing.T) {
+	r := NewReader(strings.NewReader("one\\\ntwo\nthree\n")).SetLineContinuation('\\')
+
+	out, err := r.ReadAllSliceString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"onetwo\n", "three\n"}
+	if len(out) != len(want) {
+		t.Fatalf("got %#v", out)
+	}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Fatalf("got %#v", out)
+		}
+	}
+}
+
+// TestSetLineContinuationDanglingAtEOF checks that a marker on the final, unterminated
+// line of the input (nothing left to join with) is preserved rather than dropped.
+func TestSetLineContinuationDanglingAtEOF(t *testing.T) {
+	r := NewReader(strings.NewReader("one\ntwo\\")).SetLineContinuation('\\')
+
+	out, err := r.ReadAllSliceString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"one\n", "two\\"}
+	if len(out) != len(want) {
+		t.Fatalf("got %#v", out)
+	}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Fatalf("got %#v", out)
+		}
+	}
+}
+
+// TestSetLineContinuationCountsLogicalLines checks that NR counts the joined logical
+// line once, not once per physical line it was assembled from.
+func TestSetLineContinuationCountsLogicalLines(t *testing.T) {
+	r := NewReader(strings.NewReader("a\\\nb\\\nc\ndone\n")).SetLineContinuation('\\')
+
+	var nrs []int
+	r.Each(func(line []byte) {
+		nrs = append(nrs, r.awkVars.NR)
+	})
+	if err := r.Discard(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(nrs) != 2 || nrs[0] != 1 || nrs[1] != 2 {
+		t.Fatalf("expected NR to count 2 logical lines, got %#v", nrs)
+	}
+}
+
+// TestSetLineContinuationBufferTooLong checks that a continuation-joined record still
+// surfaces ErrRecordTooLong when the assembled logical line exceeds the configured
+// scan buffer, the same as an unjoined over-long line would.
+func TestSetLineContinuationBufferTooLong(t *testing.T) {
+	long := strings.Repeat("x", 200)
+	r := NewReader(strings.NewReader("short\\\n"+long+"\n")).
+		SetLineContinuation('\\').
+		SetBufferSize(16, 32)
+
+	_, err := r.ReadAllString()
+	if err != ErrRecordTooLong {
+		t.Fatalf("expected ErrRecordTooLong, got %v", err)
+	}
+}
+
+// TestEach checks that Each invokes its callback for every line and leaves the lines
+// themselves unmodified.
+func TestEach(t *testing.T) {
+	r := NewReader(strings.NewReader("one\ntwo\nthree\n"))
+
+	var seen []string
+	r.Each(func(line []byte) {
+		seen = append(seen, string(line))
+	})
+
+	out, err := r.ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "one\ntwo\nthree\n" {
+		t.Fatalf("got %q", out)
+	}
+
+	want := []string{"one\n", "two\n", "three\n"}
+	if len(seen) != len(want) {
+		t.Fatalf("got %#v", seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("got %#v", seen)
+		}
+	}
+}
+
+// TestEachString checks the string variant of Each.
+func TestEachString(t *testing.T) {
+	r := NewReader(strings.NewReader("one\ntwo\n"))
+
+	var seen []string
+	r.EachString(func(line string) {
+		seen = append(seen, line)
+	})
+
+	if err := r.Discard(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"one\n", "two\n"}
+	if len(seen) != len(want) {
+		t.Fatalf("got %#v", seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("got %#v", seen)
+		}
+	}
+}
+
+// TestEachErrOmitLine checks that EachErr's callback can drop the current line by
+// returning ErrOmitLine, as its doc comment promises.
+func TestEachErrOmitLine(t *testing.T) {
+	r := NewReader(strings.NewReader("one\ntwo\nthree\n"))
+
+	r.EachErr(func(line []byte) error {
+		if string(line) == "two\n" {
+			return ErrOmitLine
+		}
+		return nil
+	})
+
+	out, err := r.ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "one\nthree\n" {
+		t.Fatalf("got %q", out)
+	}
+}
+
+// TestEachErrPropagation checks that an error other than ErrOmitLine returned from
+// EachErr's callback halts the stream and surfaces from Read.
+func TestEachErrPropagation(t *testing.T) {
+	boom := errors.New("boom")
+	r := NewReader(strings.NewReader("one\ntwo\nthree\n"))
+
+	r.EachErr(func(line []byte) error {
+		if string(line) == "two\n" {
+			return boom
+		}
+		return nil
+	})
+
+	_, err := r.ReadAllString()
+	if err != boom {
+		t.Fatalf("expected boom, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package byline
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// SetInputMode - set how raw input is parsed into records (PlainMode, CSVMode or TSVMode)
+func (lr *Reader) SetInputMode(mode InputMode) *Reader {
+	lr.inputMode = mode
+	return lr
+}
+
+// SetCSVComma - set the field delimiter used in CSVMode/TSVMode, see encoding/csv.Reader.Comma
+// (default is ',' for CSVMode and '\t' for TSVMode)
+func (lr *Reader) SetCSVComma(comma rune) *Reader {
+	lr.csvComma = comma
+	lr.csvCommaSet = true
+	return lr
+}
+
+// SetCSVComment - set the comment character for CSVMode/TSVMode, see encoding/csv.Reader.Comment
+func (lr *Reader) SetCSVComment(comment rune) *Reader {
+	lr.csvComment = comment
+	return lr
+}
+
+// SetCSVLazyQuotes - set lazy quotes parsing for CSVMode/TSVMode, see encoding/csv.Reader.LazyQuotes
+func (lr *Reader) SetCSVLazyQuotes(lazy bool) *Reader {
+	lr.csvLazyQuotes = lazy
+	return lr
+}
+
+// CSVMode - process records with CSV/TSV like mode, requires SetInputMode(CSVMode) or
+// SetInputMode(TSVMode) to be set beforehand. filterFn receives the parsed fields of the
+// current record and the returned fields are re-serialized with csv.Writer, so later
+// filters in the chain (MapString, Grep, ...) still see a valid CSV/TSV record. A field
+// holding a newline stays quoted with the newline in it, so such a record spans more
+// than one physical line.
+func (lr *Reader) CSVMode(filterFn func(row []string, vars AWKVars) ([]string, error)) *Reader {
+	lr.csvFilterFn = filterFn
+	return lr
+}
+
+// csvDialectReader - lazily build the csv.Reader reading directly from the underlying source,
+// so that records spanning multiple physical lines (quoted fields containing newlines) are
+// consumed whole, rather than being cut by the line-oriented scanner.
+func (lr *Reader) csvDialectReader() *csv.Reader {
+	if lr.csvReader == nil {
+		comma := lr.csvComma
+		if !lr.csvCommaSet {
+			comma = ','
+			if lr.inputMode == TSVMode {
+				comma = '\t'
+			}
+		}
+
+		lr.csvReader = csv.NewReader(lr.src)
+		lr.csvReader.Comma = comma
+		lr.csvReader.Comment = lr.csvComment
+		lr.csvReader.LazyQuotes = lr.csvLazyQuotes
+		lr.csvReader.FieldsPerRecord = -1
+	}
+
+	return lr.csvReader
+}
+
+func (lr *Reader) encodeCSVRecord(record []string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	w.Comma = lr.csvDialectReader().Comma
+	if err := w.Write(record); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readCSV - Read implementation used when inputMode is CSVMode or TSVMode
+func (lr *Reader) readCSV(p []byte) (n int, err error) {
+	var (
+		bufErr    error
+		lineBytes []byte
+	)
+
+	record, readErr := lr.csvDialectReader().Read()
+	if readErr != nil {
+		bufErr = readErr
+		lineBytes = nullBytes
+	} else {
+		lr.awkVars.NR++
+		lr.awkVars.NF = len(record)
+
+		if lr.csvFilterFn != nil {
+			newRecord, filterErr := lr.csvFilterFn(record, lr.awkVars)
+			switch {
+			case filterErr == ErrOmitLine:
+				record = nil
+			case filterErr != nil:
+				bufErr = filterErr
+				record = nil
+			default:
+				record = newRecord
+			}
+		}
+
+		if record != nil {
+			if encoded, encErr := lr.encodeCSVRecord(record); encErr != nil {
+				bufErr = encErr
+			} else {
+				lineBytes = encoded
+			}
+		}
+
+		for _, filterFunc := range lr.filterFuncs {
+			if lineBytes == nil || bufErr != nil {
+				break
+			}
+
+			var filterErr error
+			lineBytes, filterErr = filterFunc(lineBytes)
+			if filterErr != nil {
+				switch {
+				case filterErr == ErrOmitLine:
+					lineBytes = nullBytes
+				case filterErr != nil:
+					bufErr = filterErr
+				}
+				break
+			}
+		}
+	}
+
+	return lr.deliver(p, lineBytes, bufErr)
+}
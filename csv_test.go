@@ -0,0 +1,52 @@
+package byline
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCSVModeQuotedNewline checks that a CSV record whose quoted field embeds a raw
+// newline is consumed as a single record, rather than being cut at the embedded
+// newline by the line-oriented scanner.
+func TestCSVModeQuotedNewline(t *testing.T) {
+	input := "a,\"b\nc\",d\ne,f,g\n"
+	r := NewReader(strings.NewReader(input)).SetInputMode(CSVMode)
+
+	var rows [][]string
+	r.CSVMode(func(row []string, vars AWKVars) ([]string, error) {
+		rows = append(rows, append([]string(nil), row...))
+		return row, nil
+	})
+
+	if err := r.Discard(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 records, got %d: %#v", len(rows), rows)
+	}
+	if rows[0][0] != "a" || rows[0][1] != "b\nc" || rows[0][2] != "d" {
+		t.Fatalf("unexpected first record: %#v", rows[0])
+	}
+	if rows[1][0] != "e" || rows[1][1] != "f" || rows[1][2] != "g" {
+		t.Fatalf("unexpected second record: %#v", rows[1])
+	}
+}
+
+func TestTSVMode(t *testing.T) {
+	r := NewReader(strings.NewReader("a\tb\tc\n")).SetInputMode(TSVMode)
+
+	var rows [][]string
+	r.CSVMode(func(row []string, vars AWKVars) ([]string, error) {
+		rows = append(rows, append([]string(nil), row...))
+		return row, nil
+	})
+
+	if err := r.Discard(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 1 || rows[0][0] != "a" || rows[0][1] != "b" || rows[0][2] != "c" {
+		t.Fatalf("got %#v", rows)
+	}
+}
@@ -0,0 +1,68 @@
+package byline
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os/exec"
+)
+
+// Pipe - insert a filter stage that streams each record through an external command's
+// stdin and reads the transformed record back from its stdout, similar to AWK's
+// `| "cmd"` construct. The command is spawned once and kept running across records:
+// a goroutine feeds it records read from the upstream chain while Read scans its
+// stdout for the transformed ones, shutting the process down once the upstream Reader
+// reaches EOF.
+func (lr *Reader) Pipe(name string, args ...string) *Reader {
+	return lr.PipeContext(context.Background(), name, args...)
+}
+
+// PipeContext - like Pipe, but runs the command under ctx so it can be cancelled or
+// given a deadline
+func (lr *Reader) PipeContext(ctx context.Context, name string, args ...string) *Reader {
+	upstream := *lr
+
+	cmd := exec.CommandContext(ctx, name, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		lr.pipeStartErr = err
+		return lr
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		lr.pipeStartErr = err
+		return lr
+	}
+
+	if err := cmd.Start(); err != nil {
+		lr.pipeStartErr = err
+		return lr
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, copyErr := io.Copy(stdin, &upstream)
+		stdin.Close()
+		if waitErr := cmd.Wait(); copyErr == nil {
+			copyErr = waitErr
+		}
+		errCh <- copyErr
+		close(errCh)
+	}()
+
+	lr.scanner = bufio.NewScanner(stdout)
+	lr.scanner.Split(lr.scanLinesWithNL)
+	lr.src = stdout
+	lr.filterFuncs = nil
+	lr.inputMode = PlainMode
+	lr.csvReader = nil
+	lr.continuation = 0
+	lr.pipeErrCh = errCh
+	lr.awkVars = AWKVars{RS: lr.awkVars.RS, FS: lr.awkVars.FS}
+	lr.pending = nil
+	lr.pendingErr = nil
+
+	return lr
+}
@@ -0,0 +1,91 @@
+package byline
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipeSort(t *testing.T) {
+	r := NewReader(strings.NewReader("banana\napple\ncherry\n")).Pipe("sort")
+
+	lines, err := r.ReadAllSliceString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"apple\n", "banana\n", "cherry\n"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %#v", lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("got %#v", lines)
+		}
+	}
+}
+
+func TestPipeCat(t *testing.T) {
+	r := NewReader(strings.NewReader("a\nb\n")).Pipe("cat")
+
+	out, err := r.ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "a\nb\n" {
+		t.Fatalf("got %q", out)
+	}
+}
+
+// TestPipeResetsNR checks that NR restarts from 1 for the downstream (post-Pipe)
+// stream, instead of carrying over the count from records consumed before Pipe was
+// called.
+func TestPipeResetsNR(t *testing.T) {
+	r := NewReader(strings.NewReader("one\ntwo\nthree\n"))
+
+	buf := make([]byte, 32)
+	if _, err := r.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	if r.awkVars.NR != 1 {
+		t.Fatalf("expected NR=1 after first read, got %d", r.awkVars.NR)
+	}
+
+	r.Pipe("cat")
+
+	var nrs []int
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			nrs = append(nrs, r.awkVars.NR)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if len(nrs) != 2 || nrs[0] != 1 || nrs[1] != 2 {
+		t.Fatalf("expected downstream NR to restart at 1,2 got %#v", nrs)
+	}
+}
+
+// TestPipeClearsPendingRecord checks that a record fragment left buffered by a
+// partial Read (see deliver) isn't handed out a second time once Pipe has
+// repurposed the Reader to read from the piped command's stdout instead.
+func TestPipeClearsPendingRecord(t *testing.T) {
+	r := NewReader(strings.NewReader("hello-world\nsecond\n"))
+
+	buf := make([]byte, 4)
+	if _, err := r.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Pipe("cat")
+
+	out, err := r.ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "o-world\nsecond\n" {
+		t.Fatalf("got %q", out)
+	}
+}
@@ -0,0 +1,91 @@
+package byline
+
+import "regexp"
+
+// Sub - replace all matches of re in each line with repl, like sed's s/re/repl/g
+func (lr *Reader) Sub(re *regexp.Regexp, repl string) *Reader {
+	replBytes := []byte(repl)
+	return lr.Map(func(line []byte) []byte {
+		return re.ReplaceAll(line, replBytes)
+	})
+}
+
+// SubFunc - replace all matches of re in each line with the result of fn
+func (lr *Reader) SubFunc(re *regexp.Regexp, fn func([]byte) []byte) *Reader {
+	return lr.Map(func(line []byte) []byte {
+		return re.ReplaceAllFunc(line, fn)
+	})
+}
+
+// SubString - string variant of Sub
+func (lr *Reader) SubString(re *regexp.Regexp, repl string) *Reader {
+	return lr.MapString(func(line string) string {
+		return re.ReplaceAllString(line, repl)
+	})
+}
+
+// SubFuncString - string variant of SubFunc
+func (lr *Reader) SubFuncString(re *regexp.Regexp, fn func(string) string) *Reader {
+	return lr.MapString(func(line string) string {
+		return re.ReplaceAllStringFunc(line, fn)
+	})
+}
+
+// SubFirst - replace only the first match of re in each line with repl, like sed's
+// s/re/repl/ (without the g flag)
+func (lr *Reader) SubFirst(re *regexp.Regexp, repl string) *Reader {
+	replBytes := []byte(repl)
+	return lr.Map(func(line []byte) []byte {
+		return subFirst(re, line, replBytes)
+	})
+}
+
+// SubFirstFunc - replace only the first match of re in each line with the result of fn
+func (lr *Reader) SubFirstFunc(re *regexp.Regexp, fn func([]byte) []byte) *Reader {
+	return lr.Map(func(line []byte) []byte {
+		loc := re.FindIndex(line)
+		if loc == nil {
+			return line
+		}
+
+		result := make([]byte, 0, len(line))
+		result = append(result, line[:loc[0]]...)
+		result = append(result, fn(line[loc[0]:loc[1]])...)
+		result = append(result, line[loc[1]:]...)
+		return result
+	})
+}
+
+// SubFirstString - string variant of SubFirst
+func (lr *Reader) SubFirstString(re *regexp.Regexp, repl string) *Reader {
+	return lr.MapString(func(line string) string {
+		return string(subFirst(re, []byte(line), []byte(repl)))
+	})
+}
+
+// SubFirstFuncString - string variant of SubFirstFunc
+func (lr *Reader) SubFirstFuncString(re *regexp.Regexp, fn func(string) string) *Reader {
+	return lr.MapString(func(line string) string {
+		loc := re.FindStringIndex(line)
+		if loc == nil {
+			return line
+		}
+
+		return line[:loc[0]] + fn(line[loc[0]:loc[1]]) + line[loc[1]:]
+	})
+}
+
+// subFirst - splice repl (expanding $1-style backreferences, like ReplaceAll does) in
+// place of the first match of re in line, leaving the rest of line untouched
+func subFirst(re *regexp.Regexp, line, repl []byte) []byte {
+	loc := re.FindSubmatchIndex(line)
+	if loc == nil {
+		return line
+	}
+
+	result := make([]byte, 0, len(line))
+	result = append(result, line[:loc[0]]...)
+	result = re.Expand(result, repl, line, loc)
+	result = append(result, line[loc[1]:]...)
+	return result
+}
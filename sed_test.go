@@ -0,0 +1,59 @@
+package byline
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSub(t *testing.T) {
+	r := NewReader(strings.NewReader("foo bar foo\n"))
+	re := regexp.MustCompile("foo")
+
+	out, err := r.Sub(re, "X").ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out != "X bar X\n" {
+		t.Fatalf("got %q", out)
+	}
+}
+
+func TestSubFirst(t *testing.T) {
+	r := NewReader(strings.NewReader("foo bar foo\n"))
+	re := regexp.MustCompile("foo")
+
+	out, err := r.SubFirst(re, "X").ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out != "X bar foo\n" {
+		t.Fatalf("got %q", out)
+	}
+}
+
+// TestSubFirstBackreferences checks that SubFirst expands $1-style backreferences the
+// same way Sub (and regexp.ReplaceAll) does, rather than splicing repl in verbatim.
+func TestSubFirstBackreferences(t *testing.T) {
+	re := regexp.MustCompile(`(\w+)@(\w+)`)
+
+	r := NewReader(strings.NewReader("foo@bar\n"))
+	out, err := r.Sub(re, "$2@$1").ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "bar@foo\n" {
+		t.Fatalf("Sub: got %q", out)
+	}
+
+	rf := NewReader(strings.NewReader("foo@bar\n"))
+	outFirst, err := rf.SubFirst(re, "$2@$1").ReadAllString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outFirst != "bar@foo\n" {
+		t.Fatalf("SubFirst: got %q", outFirst)
+	}
+}
@@ -0,0 +1,202 @@
+package byline
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Writer - line by line Writer, the symmetric counterpart to Reader
+type Writer struct {
+	writer      io.Writer
+	filterFuncs []func(line []byte) ([]byte, error)
+
+	ofs []byte
+	ors []byte
+
+	outputMode  InputMode
+	csvComma    rune
+	csvCommaSet bool
+}
+
+// NewWriter - get new line by line Writer
+func NewWriter(writer io.Writer) *Writer {
+	return &Writer{
+		writer: writer,
+		ofs:    []byte(" "),
+		ors:    []byte("\n"),
+	}
+}
+
+// applyFilters - run the filter chain over p, returning the (possibly transformed)
+// record, or a nil slice if a filter omitted it via ErrOmitLine
+func (w *Writer) applyFilters(p []byte) ([]byte, error) {
+	line := append([]byte(nil), p...)
+
+	for _, filterFunc := range w.filterFuncs {
+		var filterErr error
+		line, filterErr = filterFunc(line)
+		if filterErr != nil {
+			if filterErr == ErrOmitLine {
+				return nil, nil
+			}
+
+			return nil, filterErr
+		}
+	}
+
+	return line, nil
+}
+
+// Write - implement io.Writer interface, applying the filter chain to whatever bytes p
+// holds. A byline Reader can be piped straight into a Writer with
+// io.Copy(writer, reader); because a single Reader record can be split across several
+// Read calls (e.g. records near SetBufferSize's limit), Write does not assume p is a
+// whole record and never appends ORS itself — use WriteLine/WriteFields for that.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	line, err := w.applyFilters(p)
+	if err != nil {
+		return 0, err
+	}
+	if line == nil {
+		return len(p), nil
+	}
+
+	if _, err := w.writer.Write(line); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// WriteLine - write a single, complete record, applying the filter chain and appending
+// ORS if it doesn't already end with it
+func (w *Writer) WriteLine(line []byte) error {
+	filtered, err := w.applyFilters(line)
+	if err != nil {
+		return err
+	}
+	if filtered == nil {
+		return nil
+	}
+
+	if _, err := w.writer.Write(filtered); err != nil {
+		return err
+	}
+
+	if !bytes.HasSuffix(filtered, w.ors) {
+		if _, err := w.writer.Write(w.ors); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteFields - join fields with OFS (or encode them per SetOutputMode) and write the
+// resulting record
+func (w *Writer) WriteFields(fields []string) error {
+	if w.outputMode != PlainMode {
+		return w.writeCSVFields(fields)
+	}
+
+	return w.WriteLine([]byte(strings.Join(fields, string(w.ofs))))
+}
+
+func (w *Writer) writeCSVFields(fields []string) error {
+	buf := &bytes.Buffer{}
+	cw := csv.NewWriter(buf)
+	cw.Comma = w.csvDialectComma()
+	if err := cw.Write(fields); err != nil {
+		return err
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	return w.WriteLine(bytes.TrimRight(buf.Bytes(), "\r\n"))
+}
+
+func (w *Writer) csvDialectComma() rune {
+	if w.csvCommaSet {
+		return w.csvComma
+	}
+	if w.outputMode == TSVMode {
+		return '\t'
+	}
+
+	return ','
+}
+
+// AWKPrint - join args with OFS and append ORS, mirroring goawk's printArgs
+func (w *Writer) AWKPrint(args ...interface{}) error {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprint(arg)
+	}
+
+	filtered, err := w.applyFilters([]byte(strings.Join(parts, string(w.ofs))))
+	if err != nil || filtered == nil {
+		return err
+	}
+	if _, err := w.writer.Write(filtered); err != nil {
+		return err
+	}
+
+	_, err = w.writer.Write(w.ors)
+	return err
+}
+
+// Map - set filter function for transforming each record before it is written
+func (w *Writer) Map(filterFn func([]byte) []byte) *Writer {
+	return w.MapErr(func(line []byte) ([]byte, error) {
+		return filterFn(line), nil
+	})
+}
+
+// MapErr - set filter function for transforming each record before it is written,
+// returns error if needed
+func (w *Writer) MapErr(filterFn func([]byte) ([]byte, error)) *Writer {
+	w.filterFuncs = append(w.filterFuncs, filterFn)
+	return w
+}
+
+// Grep - only write records matched by filterFn
+func (w *Writer) Grep(filterFn func([]byte) bool) *Writer {
+	return w.MapErr(func(line []byte) ([]byte, error) {
+		if filterFn(line) {
+			return line, nil
+		}
+
+		return nullBytes, ErrOmitLine
+	})
+}
+
+// SetOFS - set the output field separator used by WriteFields and AWKPrint (default " ")
+func (w *Writer) SetOFS(ofs string) *Writer {
+	w.ofs = []byte(ofs)
+	return w
+}
+
+// SetORS - set the output record separator appended to each record (default "\n")
+func (w *Writer) SetORS(ors string) *Writer {
+	w.ors = []byte(ors)
+	return w
+}
+
+// SetOutputMode - set output mode (PlainMode, CSVMode or TSVMode) used by WriteFields
+func (w *Writer) SetOutputMode(mode InputMode) *Writer {
+	w.outputMode = mode
+	return w
+}
+
+// SetCSVComma - set the field delimiter used when output mode is CSVMode/TSVMode
+// (default is ',' for CSVMode and '\t' for TSVMode)
+func (w *Writer) SetCSVComma(comma rune) *Writer {
+	w.csvComma = comma
+	w.csvCommaSet = true
+	return w
+}
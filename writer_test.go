@@ -0,0 +1,65 @@
+package byline
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriterPipeline(t *testing.T) {
+	r := NewReader(strings.NewReader("hello\nworld\n"))
+	buf := &bytes.Buffer{}
+
+	if _, err := io.Copy(NewWriter(buf), r); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buf.String(); got != "hello\nworld\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+// TestWriterPipelineLongRecord exercises the documented
+// io.Copy(byline.NewWriter(dst), byline.NewReader(src)) pattern with a record larger
+// than io.Copy's internal 32KiB buffer, which used to make Reader.Read return n larger
+// than the buffer it was given and panic inside io.Copy.
+func TestWriterPipelineLongRecord(t *testing.T) {
+	long := strings.Repeat("x", 40000)
+	r := NewReader(strings.NewReader(long + "\n"))
+	buf := &bytes.Buffer{}
+
+	if _, err := io.Copy(NewWriter(buf), r); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buf.String(); got != long+"\n" {
+		t.Fatalf("got len=%d, want len=%d", len(got), len(long)+1)
+	}
+}
+
+func TestWriteFieldsCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf).SetOutputMode(CSVMode)
+
+	if err := w.WriteFields([]string{"a", "b,c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buf.String(); got != "a,\"b,c\"\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestAWKPrint(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf).SetOFS(",")
+
+	if err := w.AWKPrint("a", 1, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buf.String(); got != "a,1,true\n" {
+		t.Fatalf("got %q", got)
+	}
+}